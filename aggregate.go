@@ -0,0 +1,71 @@
+package ipcalc
+
+import "sort"
+
+// Aggregate takes an arbitrary list of possibly-overlapping, possibly-adjacent
+// prefixes and returns the minimal covering set.
+func Aggregate(nets []Ipv4) []Ipv4 {
+	if len(nets) == 0 {
+		return []Ipv4{}
+	}
+
+	sorted := make([]Ipv4, len(nets))
+	copy(sorted, nets)
+	sort.Slice(sorted, func(a, b int) bool {
+		na, nb := sorted[a].Network(), sorted[b].Network()
+		if na != nb {
+			return na < nb
+		}
+		return sorted[a].Mask < sorted[b].Mask
+	})
+
+	var swept []Ipv4
+	for _, n := range sorted {
+		if (len(swept) > 0) && containsIpv4(swept[len(swept)-1], n) {
+			continue
+		}
+		swept = append(swept, n)
+	}
+
+	for {
+		merged, changed := mergeAdjacent(swept)
+		swept = merged
+		if !changed {
+			break
+		}
+	}
+
+	return swept
+}
+
+// containsIpv4 returns true if all of inner falls within the bounds of outer.
+func containsIpv4(outer, inner Ipv4) bool {
+	return (inner.Network() >= outer.Network()) && (inner.Broadcast() <= outer.Broadcast())
+}
+
+// mergeAdjacent makes a single pass over a sorted, non-overlapping slice of
+// prefixes, combining any two adjacent same-length prefixes into one prefix
+// one bit shorter whenever the lower one's network address is aligned to
+// that shorter mask.
+func mergeAdjacent(nets []Ipv4) ([]Ipv4, bool) {
+	var res []Ipv4
+	changed := false
+
+	i := 0
+	for i < len(nets) {
+		if i+1 < len(nets) && nets[i].Mask == nets[i+1].Mask {
+			widerMask := nets[i].Mask << 1
+			merged := Ipv4{Addr: nets[i].Network(), Mask: widerMask}
+			if (merged.Network() == nets[i].Network()) && (merged.Broadcast() == nets[i+1].Broadcast()) {
+				res = append(res, merged)
+				changed = true
+				i += 2
+				continue
+			}
+		}
+		res = append(res, nets[i])
+		i++
+	}
+
+	return res, changed
+}
@@ -0,0 +1,48 @@
+package ipcalc
+
+import (
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	a, _ := CidrToIpv4("10.0.0.0/25")
+	b, _ := CidrToIpv4("10.0.0.128/25")
+	c, _ := CidrToIpv4("10.0.1.0/24")
+	contained, _ := CidrToIpv4("10.0.0.64/26")
+	merged, _ := CidrToIpv4("10.0.0.0/23")
+
+	t.Log("Testing route summarization.")
+	{
+		t.Logf("\tTest 0: adjacent same-length prefixes merge")
+		{
+			res := Aggregate([]Ipv4{a, b})
+			want, _ := CidrToIpv4("10.0.0.0/24")
+			if len(res) != 1 || res[0] != want {
+				t.Fatalf("\tExpected a single /24, got %v", res)
+			}
+		}
+		t.Logf("\tTest 1: contained prefixes are dropped")
+		{
+			res := Aggregate([]Ipv4{a, contained})
+			if len(res) != 1 || res[0] != a {
+				t.Fatalf("\tExpected just the /25, got %v", res)
+			}
+		}
+		t.Logf("\tTest 2: repeated merges cascade")
+		{
+			res := Aggregate([]Ipv4{a, b, c})
+			if len(res) != 1 || res[0] != merged {
+				t.Fatalf("\tExpected a single /23, got %v", res)
+			}
+		}
+		t.Logf("\tTest 3: a narrower and wider prefix sharing the same network dedupe")
+		{
+			narrow, _ := CidrToIpv4("10.0.0.0/32")
+			wide, _ := CidrToIpv4("10.0.0.0/24")
+			res := Aggregate([]Ipv4{narrow, wide})
+			if len(res) != 1 || res[0] != wide {
+				t.Fatalf("\tExpected just the /24, got %v", res)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package ipcalc
+
+import (
+	"errors"
+	"strconv"
+)
+
+// maskPrefixLen returns the CIDR prefix length represented by a contiguous mask.
+func maskPrefixLen(m uint32) int {
+	for i := 0; i < 32; i++ {
+		if m == TwoFiveFive {
+			return 32 - i
+		}
+		m = uint32(1)<<31 + m>>1
+	}
+	return 0
+}
+
+// AddressCount returns the number of addresses contained within the prefix i describes.
+func (i *Ipv4) AddressCount() uint64 {
+	return uint64(1) << uint(32-maskPrefixLen(i.Mask))
+}
+
+// Host returns the address of the hostNum'th host inside the prefix i
+// describes, counting the network address as host 0. A negative hostNum
+// counts backward from the broadcast address, with -1 selecting the
+// broadcast address itself.
+func (i *Ipv4) Host(hostNum int) (uint32, error) {
+	count := i.AddressCount()
+
+	var idx int64
+	if hostNum < 0 {
+		idx = int64(count) + int64(hostNum)
+	} else {
+		idx = int64(hostNum)
+	}
+	if (idx < 0) || (uint64(idx) >= count) {
+		return 0, errors.New("Host number out of range for prefix")
+	}
+	return i.Network() + uint32(idx), nil
+}
+
+// SubnetByBits returns the num'th subnet produced by extending i's prefix by newBits.
+func (i *Ipv4) SubnetByBits(newBits int, num int) (Ipv4, error) {
+	newLen := maskPrefixLen(i.Mask) + newBits
+	if (newBits < 0) || (newLen > 32) {
+		return Ipv4{}, errors.New("Not enough bits to create a subnet of that size")
+	}
+	if (num < 0) || (uint64(num) >= uint64(1)<<uint(newBits)) {
+		return Ipv4{}, errors.New("Subnet number out of range for the requested newBits")
+	}
+
+	mask, err := stringToMask(strconv.Itoa(newLen))
+	if err != nil {
+		return Ipv4{}, err
+	}
+	count := uint64(1) << uint(32-newLen)
+	addr := i.Network() + uint32(uint64(num)*count)
+	return Ipv4{Addr: addr, Mask: mask}, nil
+}
+
+// NextSubnet returns the prefix of the same size immediately following i.
+func (i *Ipv4) NextSubnet() (Ipv4, error) {
+	count := i.AddressCount()
+	next := uint64(i.Network()) + count
+	if next+count-1 > uint64(TwoFiveFive) {
+		return Ipv4{}, errors.New("No next subnet: would overflow the address space")
+	}
+	return Ipv4{Addr: uint32(next), Mask: i.Mask}, nil
+}
+
+// PreviousSubnet returns the prefix of the same size immediately preceding i.
+func (i *Ipv4) PreviousSubnet() (Ipv4, error) {
+	count := i.AddressCount()
+	if uint64(i.Network()) < count {
+		return Ipv4{}, errors.New("No previous subnet: would underflow the address space")
+	}
+	return Ipv4{Addr: uint32(uint64(i.Network()) - count), Mask: i.Mask}, nil
+}
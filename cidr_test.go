@@ -0,0 +1,109 @@
+package ipcalc
+
+import (
+	"testing"
+)
+
+func TestCidrArithmetic(t *testing.T) {
+	n, _ := CidrToIpv4("10.0.0.0/24")
+
+	t.Log("Testing CIDR arithmetic helpers.")
+	{
+		t.Logf("\tTest 0: AddressCount")
+		{
+			if c := n.AddressCount(); c != 256 {
+				t.Fatalf("\tExpected 256 addresses, got %v", c)
+			}
+		}
+		t.Logf("\tTest 1: Host")
+		{
+			h, err := n.Host(5)
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			want, _ := CidrToIpv4("10.0.0.5/32")
+			if h != want.Addr {
+				t.Fatalf("\tExpected 10.0.0.5, got %v", addrToString(h))
+			}
+		}
+		t.Logf("\tTest 2: Host with negative index")
+		{
+			h, err := n.Host(-1)
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			want, _ := CidrToIpv4("10.0.0.255/32")
+			if h != want.Addr {
+				t.Fatalf("\tExpected 10.0.0.255, got %v", addrToString(h))
+			}
+		}
+		t.Logf("\tTest 3: SubnetByBits")
+		{
+			s, err := n.SubnetByBits(2, 1)
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			want, _ := CidrToIpv4("10.0.0.64/26")
+			if s != want {
+				t.Fatalf("\tExpected 10.0.0.64/26, got %v", s)
+			}
+		}
+		t.Logf("\tTest 4: NextSubnet")
+		{
+			next, err := n.NextSubnet()
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			want, _ := CidrToIpv4("10.0.1.0/24")
+			if next != want {
+				t.Fatalf("\tExpected 10.0.1.0/24, got %v", next)
+			}
+		}
+		t.Logf("\tTest 5: PreviousSubnet")
+		{
+			prev, err := n.PreviousSubnet()
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			prevWant, _ := CidrToIpv4("9.255.255.0/24")
+			if prev != prevWant {
+				t.Fatalf("\tExpected 9.255.255.0/24, got %v", prev)
+			}
+		}
+		t.Logf("\tTest 6: Host rejects an out-of-range hostNum")
+		{
+			if _, err := n.Host(256); err == nil {
+				t.Fatalf("\tExpected an error for a hostNum beyond the prefix")
+			}
+			if _, err := n.Host(-257); err == nil {
+				t.Fatalf("\tExpected an error for a negative hostNum beyond the prefix")
+			}
+		}
+		t.Logf("\tTest 7: SubnetByBits rejects newBits that don't fit")
+		{
+			if _, err := n.SubnetByBits(9, 0); err == nil {
+				t.Fatalf("\tExpected an error when newBits would extend past /32")
+			}
+		}
+		t.Logf("\tTest 8: SubnetByBits rejects an out-of-range num")
+		{
+			if _, err := n.SubnetByBits(2, 4); err == nil {
+				t.Fatalf("\tExpected an error for a subnet number beyond newBits")
+			}
+		}
+		t.Logf("\tTest 9: NextSubnet overflows past 255.255.255.255")
+		{
+			top, _ := CidrToIpv4("255.255.255.0/24")
+			if _, err := top.NextSubnet(); err == nil {
+				t.Fatalf("\tExpected an error when the next subnet would overflow the address space")
+			}
+		}
+		t.Logf("\tTest 10: PreviousSubnet underflows past 0.0.0.0")
+		{
+			bottom, _ := CidrToIpv4("0.0.0.0/24")
+			if _, err := bottom.PreviousSubnet(); err == nil {
+				t.Fatalf("\tExpected an error when the previous subnet would underflow the address space")
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package ipcalc
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Coalesce groups addresses sharing a coalesceMaskLen-bit prefix and, when a
+// group contains at least minCount members, emits the aggregate prefix in
+// place of the individual /32s it replaces. Groups below minCount are
+// returned as their original /32s. Results are returned in ascending address
+// order.
+func Coalesce(ips []Ipv4, minCount int, coalesceMaskLen int) []Ipv4 {
+	mask, err := stringToMask(strconv.Itoa(coalesceMaskLen))
+	if err != nil {
+		res := make([]Ipv4, len(ips))
+		for i, ip := range ips {
+			res[i] = Ipv4{Addr: ip.Addr, Mask: TwoFiveFive}
+		}
+		return res
+	}
+
+	sorted := make([]Ipv4, len(ips))
+	copy(sorted, ips)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Addr < sorted[b].Addr })
+
+	var res []Ipv4
+	var bucket []Ipv4
+	var bucketNet uint32
+
+	flush := func() {
+		if len(bucket) == 0 {
+			return
+		}
+		if len(bucket) >= minCount {
+			res = append(res, Ipv4{Addr: bucketNet, Mask: mask})
+		} else {
+			res = append(res, bucket...)
+		}
+		bucket = nil
+	}
+
+	for _, ip := range sorted {
+		net := ip.Addr & mask
+		if (len(bucket) > 0) && (net != bucketNet) {
+			flush()
+		}
+		bucketNet = net
+		bucket = append(bucket, Ipv4{Addr: ip.Addr, Mask: TwoFiveFive})
+	}
+	flush()
+
+	return res
+}
@@ -0,0 +1,45 @@
+package ipcalc
+
+import (
+	"testing"
+)
+
+func TestCoalesce(t *testing.T) {
+	a, _ := CidrToIpv4("10.0.0.1/32")
+	b, _ := CidrToIpv4("10.0.0.2/32")
+	c, _ := CidrToIpv4("10.0.0.3/32")
+	d, _ := CidrToIpv4("10.0.1.1/32")
+	agg, _ := CidrToIpv4("10.0.0.0/24")
+
+	t.Log("Testing coalescing of IP slices.")
+	{
+		t.Logf("\tTest 0: group meeting minCount aggregates")
+		{
+			res := Coalesce([]Ipv4{c, a, b}, 2, 24)
+			if len(res) != 1 || res[0] != agg {
+				t.Fatalf("\tExpected a single aggregate, got %v", res)
+			}
+		}
+		t.Logf("\tTest 1: group below minCount stays as /32s")
+		{
+			res := Coalesce([]Ipv4{a, b, c}, 4, 24)
+			if len(res) != 3 {
+				t.Fatalf("\tExpected 3 ungrouped /32s, got %v", res)
+			}
+		}
+		t.Logf("\tTest 2: stray address outside the group is left alone")
+		{
+			res := Coalesce([]Ipv4{a, b, c, d}, 2, 24)
+			if len(res) != 2 || res[0] != agg || res[1] != d {
+				t.Fatalf("\tExpected aggregate followed by stray /32, got %v", res)
+			}
+		}
+		t.Logf("\tTest 3: an invalid coalesceMaskLen returns the inputs as /32s instead of dropping them")
+		{
+			res := Coalesce([]Ipv4{a, b, c}, 2, 99)
+			if len(res) != 3 || res[0] != a || res[1] != b || res[2] != c {
+				t.Fatalf("\tExpected the original /32s unchanged, got %v", res)
+			}
+		}
+	}
+}
@@ -26,17 +26,28 @@ func (i *Ipv4) Broadcast() uint32 {
 	return (^i.Mask) | i.Addr
 }
 
+// IpNet is implemented by both Ipv4 and Ipv6, letting callers write code that
+// works against either address family.
 type IpNet interface {
-	Network()
-	Broadcast()
+	ToCidr() (string, error)
+	IsContiguous() bool
+}
+
+// CidrStrings converts a mix of Ipv4 and Ipv6 networks to their CIDR string
+// representations, in order, skipping any with a discontiguous mask.
+func CidrStrings(nets []IpNet) []string {
+	res := make([]string, 0, len(nets))
+	for _, n := range nets {
+		if s, err := n.ToCidr(); err == nil {
+			res = append(res, s)
+		}
+	}
+	return res
 }
 
 // IsIn returns true if all of i falls within the bounds of n.
 func (i *Ipv4) IsIn(n Ipv4) bool {
-	if (i.Addr >= n.Addr) && (i.Mask <= n.Mask) {
-		return true
-	}
-	return false
+	return (i.Network() >= n.Network()) && (i.Broadcast() <= n.Broadcast())
 }
 
 // ToCidr returns a string representation of an Ipv4 object in CIDR format.
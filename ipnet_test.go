@@ -0,0 +1,27 @@
+package ipcalc
+
+import (
+	"testing"
+)
+
+func TestCidrStrings(t *testing.T) {
+	v4, _ := CidrToIpv4("10.0.0.0/24")
+	v6, _ := CidrToIpv6("2001:db8::/32")
+
+	t.Log("Testing CidrStrings across address families via IpNet.")
+	{
+		t.Logf("\tTest 0: Ipv4 and Ipv6 both satisfy IpNet")
+		{
+			res := CidrStrings([]IpNet{&v4, &v6})
+			want := []string{"10.0.0.0/24", "2001:db8::/32"}
+			if len(res) != len(want) {
+				t.Fatalf("\tExpected %v, got %v", want, res)
+			}
+			for i, s := range res {
+				if s != want[i] {
+					t.Fatalf("\tExpected %v, got %v", want[i], s)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package ipcalc
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// AllOnes64 is a 64-bit word with every bit set, the Ipv6 analogue of TwoFiveFive.
+const AllOnes64 uint64 = 1<<64 - 1
+
+// Ipv6 uses two 64-bit halves (high and low) to represent an Ipv6 address,
+// including the network mask.
+type Ipv6 struct {
+	AddrHi uint64
+	AddrLo uint64
+	MaskHi uint64
+	MaskLo uint64
+}
+
+// Network calculates and returns an Ipv6 object's network address.
+func (i *Ipv6) Network() (uint64, uint64) {
+	return i.MaskHi & i.AddrHi, i.MaskLo & i.AddrLo
+}
+
+// Broadcast calculates and returns an Ipv6 object's all-ones host address, the
+// Ipv6 equivalent of an Ipv4 broadcast address.
+func (i *Ipv6) Broadcast() (uint64, uint64) {
+	return (^i.MaskHi) | i.AddrHi, (^i.MaskLo) | i.AddrLo
+}
+
+// IsIn returns true if all of i falls within the bounds of n.
+func (i *Ipv6) IsIn(n Ipv6) bool {
+	iNetHi, iNetLo := i.Network()
+	iBcHi, iBcLo := i.Broadcast()
+	nNetHi, nNetLo := n.Network()
+	nBcHi, nBcLo := n.Broadcast()
+	return cmp128(iNetHi, iNetLo, nNetHi, nNetLo) >= 0 && cmp128(iBcHi, iBcLo, nBcHi, nBcLo) <= 0
+}
+
+// IsContiguous returns true if the Ipv6 object has a contiguous network mask.
+func (i *Ipv6) IsContiguous() bool {
+	f := false
+	for n := 0; n < 128; n++ {
+		b := bit128(i.MaskHi, i.MaskLo, n)
+		if b != 0 {
+			f = true
+		}
+		if f && (b == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddressCount returns the number of addresses contained within the prefix i
+// describes. A *big.Int is needed because a /0 Ipv6 prefix holds 2^128
+// addresses, which overflows a uint64.
+func (i *Ipv6) AddressCount() *big.Int {
+	prefixLen := prefixLen128(i.MaskHi, i.MaskLo)
+	return new(big.Int).Lsh(big.NewInt(1), uint(128-prefixLen))
+}
+
+// ToCidr returns a string representation of an Ipv6 object in CIDR format.
+func (i *Ipv6) ToCidr() (string, error) {
+	h := hi64LoToIP(i.AddrHi, i.AddrLo).String()
+	if !i.IsContiguous() {
+		return h, errors.New("Cannot represent discontiguous subnet mask in CIDR notation")
+	}
+	return h + "/" + strconv.Itoa(prefixLen128(i.MaskHi, i.MaskLo)), nil
+}
+
+// CidrToIpv6 converts a string in CIDR format to an Ipv6 object.
+// If the input string has no "/", it assumes a "/128" mask is intended.
+func CidrToIpv6(c string) (Ipv6, error) {
+	var res Ipv6
+
+	cidrArr := strings.Split(c, "/")
+
+	ip := net.ParseIP(cidrArr[0])
+	if ip == nil || ip.To4() != nil {
+		return res, errors.New("Failed to convert CIDR string to bits")
+	}
+	ip16 := ip.To16()
+	res.AddrHi = binary.BigEndian.Uint64(ip16[0:8])
+	res.AddrLo = binary.BigEndian.Uint64(ip16[8:16])
+
+	if len(cidrArr) == 2 {
+		n, err := strconv.Atoi(cidrArr[1])
+		if (err != nil) || (n < 0) || (n > 128) {
+			return res, errors.New("Invalid CIDR prefix")
+		}
+		res.MaskHi, res.MaskLo = mask128(n)
+	} else {
+		res.MaskHi, res.MaskLo = AllOnes64, AllOnes64
+	}
+	return res, nil
+}
+
+// OverlapV6 returns a slice of the networks shared by networks n1 and n2.
+func OverlapV6(n1 Ipv6, n2 Ipv6) []Ipv6 {
+	var lo Ipv6
+	var hi Ipv6
+
+	switch cmp128(n1.AddrHi, n1.AddrLo, n2.AddrHi, n2.AddrLo) {
+	case -1:
+		lo = n1
+		hi = n2
+	case 1:
+		hi = n1
+		lo = n2
+	case 0:
+		switch cmp128(n1.MaskHi, n1.MaskLo, n2.MaskHi, n2.MaskLo) {
+		case 1:
+			lo = n1
+			hi = n2
+		case -1:
+			hi = n1
+			lo = n2
+		case 0:
+			return []Ipv6{n1}
+		}
+	}
+
+	startHi, startLo := hi.AddrHi, hi.AddrLo
+	loBcHi, loBcLo := lo.Broadcast()
+	hiBcHi, hiBcLo := hi.Broadcast()
+	var stopHi, stopLo uint64
+	if cmp128(loBcHi, loBcLo, hiBcHi, hiBcLo) < 0 {
+		stopHi, stopLo = loBcHi, loBcLo
+	} else {
+		stopHi, stopLo = hiBcHi, hiBcLo
+	}
+
+	if cmp128(stopHi, stopLo, startHi, startLo) < 0 || cmp128(startHi, startLo, loBcHi, loBcLo) > 0 {
+		return []Ipv6{}
+	}
+
+	res, err := SubnetV6(startHi, startLo, stopHi, stopLo)
+	if err != nil {
+		return []Ipv6{}
+	}
+	return res
+}
+
+// SubnetV6 summarizes a range of Ipv6 addresses defined by 128-bit bounds into
+// the smallest possible amount of subnets (largest possible network sizes).
+func SubnetV6(startHi, startLo, stopHi, stopLo uint64) ([]Ipv6, error) {
+	if startHi == stopHi && startLo == stopLo {
+		return []Ipv6{{AddrHi: startHi, AddrLo: startLo, MaskHi: AllOnes64, MaskLo: AllOnes64}}, nil
+	}
+
+	if cmp128(startHi, startLo, stopHi, stopLo) > 0 {
+		return []Ipv6{}, errors.New("Argument order is backwards")
+	}
+
+	var lo Ipv6
+	lo.AddrHi, lo.AddrLo = startHi, startLo
+	sharedHi, sharedLo := startHi^stopHi, startLo^stopLo
+	for bits := 0; bits <= 128; bits++ {
+		shHi, shLo := shiftRight128(sharedHi, sharedLo, bits)
+		if shHi == 0 && shLo == 0 {
+			lo.MaskHi, lo.MaskLo = mask128(128 - bits)
+			break
+		}
+	}
+	for {
+		netHi, netLo := lo.Network()
+		if netHi == lo.AddrHi && netLo == lo.AddrLo {
+			break
+		}
+		lo.MaskHi, lo.MaskLo = shiftMaskRight128(lo.MaskHi, lo.MaskLo)
+	}
+
+	res := []Ipv6{lo}
+
+	bcHi, bcLo := lo.Broadcast()
+	if bcHi == stopHi && bcLo == stopLo {
+		return res, nil
+	}
+	if cmp128(bcHi, bcLo, stopHi, stopLo) > 0 {
+		return res, errors.New("Internal Error")
+	}
+
+	nextHi, nextLo := incr128(bcHi, bcLo)
+	next, err := SubnetV6(nextHi, nextLo, stopHi, stopLo)
+	if err != nil {
+		return res, err
+	}
+	res = append(res, next...)
+	return res, nil
+}
+
+// cmp128 compares two 128-bit unsigned integers, each split into high and low
+// 64-bit halves, returning -1, 0, or 1.
+func cmp128(aHi, aLo, bHi, bLo uint64) int {
+	switch {
+	case aHi != bHi:
+		if aHi < bHi {
+			return -1
+		}
+		return 1
+	case aLo != bLo:
+		if aLo < bLo {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
+
+// bit128 returns the nth bit (0 is the least significant) of a 128-bit value
+// split into high and low 64-bit halves.
+func bit128(hi, lo uint64, n int) uint64 {
+	if n < 64 {
+		return lo & (1 << uint(n))
+	}
+	return hi & (1 << uint(n-64))
+}
+
+// shiftRight128 performs a logical right shift of a 128-bit value by n bits.
+func shiftRight128(hi, lo uint64, n int) (uint64, uint64) {
+	if n == 0 {
+		return hi, lo
+	}
+	if n >= 128 {
+		return 0, 0
+	}
+	if n < 64 {
+		return hi >> uint(n), (lo >> uint(n)) | (hi << uint(64-n))
+	}
+	return 0, hi >> uint(n-64)
+}
+
+// shiftMaskRight128 shifts a contiguous 128-bit mask one bit to the right,
+// shortening the prefix by one bit.
+func shiftMaskRight128(hi, lo uint64) (uint64, uint64) {
+	newLo := (lo >> 1) | (hi << 63)
+	newHi := (hi >> 1) | (1 << 63)
+	return newHi, newLo
+}
+
+// incr128 adds one to a 128-bit value split into high and low 64-bit halves.
+func incr128(hi, lo uint64) (uint64, uint64) {
+	if lo == AllOnes64 {
+		return hi + 1, 0
+	}
+	return hi, lo + 1
+}
+
+// mask128 builds a 128-bit contiguous network mask n bits long.
+func mask128(n int) (uint64, uint64) {
+	if n <= 64 {
+		return prefixMask(n), 0
+	}
+	return AllOnes64, prefixMask(n - 64)
+}
+
+// prefixMask returns a 64-bit word with the top n bits set to one.
+func prefixMask(n int) uint64 {
+	if n <= 0 {
+		return 0
+	}
+	if n >= 64 {
+		return AllOnes64
+	}
+	return AllOnes64 - (uint64(1)<<uint(64-n) - 1)
+}
+
+// prefixLen128 returns the number of leading one bits in a contiguous
+// 128-bit mask.
+func prefixLen128(hi, lo uint64) int {
+	n := 0
+	for i := 63; i >= 0; i-- {
+		if hi&(1<<uint(i)) == 0 {
+			return n
+		}
+		n++
+	}
+	for i := 63; i >= 0; i-- {
+		if lo&(1<<uint(i)) == 0 {
+			return n
+		}
+		n++
+	}
+	return n
+}
+
+// hi64LoToIP builds a net.IP from the high and low 64-bit halves of an Ipv6
+// address.
+func hi64LoToIP(hi, lo uint64) net.IP {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[0:8], hi)
+	binary.BigEndian.PutUint64(b[8:16], lo)
+	return net.IP(b)
+}
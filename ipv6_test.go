@@ -0,0 +1,116 @@
+package ipcalc
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestIpv6Conv(t *testing.T) {
+	cidr := "2001:db8::/32"
+
+	t.Log("Testing Ipv6 string conversion.")
+	{
+		t.Logf("\tTest 0: string to Ipv6")
+		{
+			resp, err := CidrToIpv6(cidr)
+			if err != nil {
+				t.Fatalf("\tError on conversion : %v", err)
+			}
+			out, err := resp.ToCidr()
+			if err != nil {
+				t.Fatalf("\tError on conversion : %v", err)
+			}
+			if out != cidr {
+				t.Fatalf("\tConversion produced the wrong answer : %v", out)
+			}
+		}
+	}
+}
+
+func TestIpv6IsIn(t *testing.T) {
+	n1, _ := CidrToIpv6("2001:db8::/48")
+	n2, _ := CidrToIpv6("2001:db8::/32")
+	t.Log("Testing Ipv6 network containment detection.")
+	{
+		t.Logf("\tTest 0: 2001:db8::/48 in 2001:db8::/32")
+		{
+			if !n1.IsIn(n2) {
+				t.Fatalf("\tTest produced the wrong answer; should be true.")
+			}
+		}
+		t.Logf("\tTest 1: 2001:db8::/32 in 2001:db8::/48")
+		{
+			if n2.IsIn(n1) {
+				t.Fatalf("\tTest produced the wrong answer; should be false.")
+			}
+		}
+	}
+}
+
+func TestIpv6Broadcast(t *testing.T) {
+	n, _ := CidrToIpv6("2001:db8::/32")
+
+	t.Log("Testing Ipv6 Broadcast.")
+	{
+		t.Logf("\tTest 0: a /32 broadcast fills the host portion with ones")
+		{
+			hi, lo := n.Broadcast()
+			wantHi := uint64(0x20010db8ffffffff)
+			wantLo := uint64(0xffffffffffffffff)
+			if hi != wantHi || lo != wantLo {
+				t.Fatalf("\tExpected %#x:%#x, got %#x:%#x", wantHi, wantLo, hi, lo)
+			}
+		}
+	}
+}
+
+func TestOverlapV6(t *testing.T) {
+	n1, _ := CidrToIpv6("2001:db8::/47")
+	n2, _ := CidrToIpv6("2001:db8::/48")
+
+	t.Log("Testing OverlapV6.")
+	{
+		t.Logf("\tTest 0: a prefix fully contained in another summarizes to itself")
+		{
+			res := OverlapV6(n1, n2)
+			if len(res) != 1 || res[0] != n2 {
+				t.Fatalf("\tExpected just %v, got %v", n2, res)
+			}
+		}
+	}
+}
+
+func TestSubnetV6(t *testing.T) {
+	n, _ := CidrToIpv6("2001:db8::/63")
+
+	t.Log("Testing SubnetV6.")
+	{
+		t.Logf("\tTest 0: a range spanning exactly a prefix summarizes to that prefix")
+		{
+			startHi, startLo := n.Network()
+			stopHi, stopLo := n.Broadcast()
+			res, err := SubnetV6(startHi, startLo, stopHi, stopLo)
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			if len(res) != 1 || res[0] != n {
+				t.Fatalf("\tExpected just %v, got %v", n, res)
+			}
+		}
+	}
+}
+
+func TestIpv6AddressCount(t *testing.T) {
+	n, _ := CidrToIpv6("::/0")
+
+	t.Log("Testing Ipv6 AddressCount.")
+	{
+		t.Logf("\tTest 0: a /0 prefix holds 2^128 addresses")
+		{
+			want := new(big.Int).Lsh(big.NewInt(1), 128)
+			if n.AddressCount().Cmp(want) != 0 {
+				t.Fatalf("\tExpected %v, got %v", want, n.AddressCount())
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package ipcalc
+
+import (
+	"iter"
+	"strconv"
+)
+
+// Hosts returns an iterator over the usable host addresses within i,
+// excluding the network and broadcast addresses for prefixes shorter than
+// /31, where every address in the prefix is usable.
+func (i *Ipv4) Hosts() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		network := i.Network()
+		broadcast := i.Broadcast()
+		start, stop := network, broadcast
+		if broadcast-network > 1 {
+			start, stop = network+1, broadcast-1
+		}
+		for h := start; h <= stop; h++ {
+			if !yield(h) {
+				return
+			}
+			if h == stop {
+				break
+			}
+		}
+	}
+}
+
+// Subnets returns an iterator over every newPrefix-length prefix within i.
+func (i *Ipv4) Subnets(newPrefix int) iter.Seq[Ipv4] {
+	return func(yield func(Ipv4) bool) {
+		oldLen := maskPrefixLen(i.Mask)
+		if (newPrefix < oldLen) || (newPrefix > 32) {
+			return
+		}
+		mask, err := stringToMask(strconv.Itoa(newPrefix))
+		if err != nil {
+			return
+		}
+
+		count := uint64(1) << uint(32-newPrefix)
+		total := uint64(1) << uint(newPrefix-oldLen)
+		addr := i.Network()
+		for n := uint64(0); n < total; n++ {
+			if !yield(Ipv4{Addr: addr, Mask: mask}) {
+				return
+			}
+			addr += uint32(count)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ipcalc
+
+import (
+	"testing"
+)
+
+func TestHosts(t *testing.T) {
+	n, _ := CidrToIpv4("10.0.0.0/30")
+
+	t.Log("Testing the Hosts iterator.")
+	{
+		t.Logf("\tTest 0: excludes network and broadcast for prefixes shorter than /31")
+		{
+			var got []uint32
+			for h := range n.Hosts() {
+				got = append(got, h)
+			}
+			want := []string{"10.0.0.1", "10.0.0.2"}
+			if len(got) != len(want) {
+				t.Fatalf("\tExpected %v hosts, got %v", want, got)
+			}
+			for idx, h := range got {
+				if addrToString(h) != want[idx] {
+					t.Fatalf("\tExpected %v, got %v", want[idx], addrToString(h))
+				}
+			}
+		}
+		t.Logf("\tTest 1: stops early when the consumer stops iterating")
+		{
+			count := 0
+			for range n.Hosts() {
+				count++
+				break
+			}
+			if count != 1 {
+				t.Fatalf("\tExpected iteration to stop after 1 host, got %v", count)
+			}
+		}
+	}
+}
+
+func TestSubnets(t *testing.T) {
+	n, _ := CidrToIpv4("10.0.0.0/24")
+
+	t.Log("Testing the Subnets iterator.")
+	{
+		t.Logf("\tTest 0: yields every /26 inside a /24")
+		{
+			var got []Ipv4
+			for s := range n.Subnets(26) {
+				got = append(got, s)
+			}
+			if len(got) != 4 {
+				t.Fatalf("\tExpected 4 subnets, got %v", got)
+			}
+			first, _ := CidrToIpv4("10.0.0.0/26")
+			if got[0] != first {
+				t.Fatalf("\tExpected first subnet %v, got %v", first, got[0])
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package ipcalc
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+	"strconv"
+)
+
+// FromNetIPNet converts a *net.IPNet to an Ipv4 object.
+func FromNetIPNet(n *net.IPNet) (Ipv4, error) {
+	ip4 := n.IP.To4()
+	if ip4 == nil {
+		return Ipv4{}, errors.New("net.IPNet does not contain an Ipv4 address")
+	}
+	if len(n.Mask) != 4 {
+		return Ipv4{}, errors.New("net.IPNet does not contain an Ipv4 mask")
+	}
+	return Ipv4{
+		Addr: binary.BigEndian.Uint32(ip4),
+		Mask: binary.BigEndian.Uint32(n.Mask),
+	}, nil
+}
+
+// ToNetIPNet converts an Ipv4 object to a *net.IPNet.
+func (i *Ipv4) ToNetIPNet() *net.IPNet {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, i.Network())
+	mask := make(net.IPMask, 4)
+	binary.BigEndian.PutUint32(mask, i.Mask)
+	return &net.IPNet{IP: ip, Mask: mask}
+}
+
+// FromPrefix converts a netip.Prefix to an Ipv4 object.
+func FromPrefix(p netip.Prefix) (Ipv4, error) {
+	if !p.Addr().Is4() {
+		return Ipv4{}, errors.New("netip.Prefix does not contain an Ipv4 address")
+	}
+	mask, err := stringToMask(strconv.Itoa(p.Bits()))
+	if err != nil {
+		return Ipv4{}, err
+	}
+	b := p.Addr().As4()
+	return Ipv4{Addr: binary.BigEndian.Uint32(b[:]), Mask: mask}, nil
+}
+
+// ToPrefix converts an Ipv4 object to a netip.Prefix.
+func (i *Ipv4) ToPrefix() netip.Prefix {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], i.Addr)
+	return netip.PrefixFrom(netip.AddrFrom4(b), maskPrefixLen(i.Mask))
+}
+
+// ParseCIDR parses a CIDR string into the exact host address it names and the
+// network that contains it, mirroring the split performed by net.ParseCIDR.
+// CidrToIpv4 conflates the two by folding the host bits into the network.
+func ParseCIDR(s string) (addr Ipv4, network Ipv4, err error) {
+	addr, err = CidrToIpv4(s)
+	if err != nil {
+		return Ipv4{}, Ipv4{}, err
+	}
+	network = Ipv4{Addr: addr.Network(), Mask: addr.Mask}
+	return addr, network, nil
+}
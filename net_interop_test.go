@@ -0,0 +1,61 @@
+package ipcalc
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestNetInterop(t *testing.T) {
+	n, _ := CidrToIpv4("10.0.0.5/24")
+
+	t.Log("Testing interop with net and netip types.")
+	{
+		t.Logf("\tTest 0: ToNetIPNet / FromNetIPNet round trip")
+		{
+			std := n.ToNetIPNet()
+			back, err := FromNetIPNet(std)
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			want, _ := CidrToIpv4("10.0.0.0/24")
+			if back != want {
+				t.Fatalf("\tExpected %v, got %v", want, back)
+			}
+		}
+		t.Logf("\tTest 1: ToPrefix / FromPrefix round trip")
+		{
+			p := n.ToPrefix()
+			if p.String() != "10.0.0.5/24" {
+				t.Fatalf("\tExpected 10.0.0.5/24, got %v", p.String())
+			}
+			back, err := FromPrefix(p)
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			if back != n {
+				t.Fatalf("\tExpected %v, got %v", n, back)
+			}
+		}
+		t.Logf("\tTest 2: FromPrefix rejects Ipv6 addresses")
+		{
+			_, err := FromPrefix(netip.MustParsePrefix("2001:db8::/32"))
+			if err == nil {
+				t.Fatalf("\tExpected an error for an Ipv6 prefix")
+			}
+		}
+		t.Logf("\tTest 3: ParseCIDR splits host and network")
+		{
+			addr, network, err := ParseCIDR("10.0.0.5/24")
+			if err != nil {
+				t.Fatalf("\tUnexpected error: %v", err)
+			}
+			if addr != n {
+				t.Fatalf("\tExpected host %v, got %v", n, addr)
+			}
+			wantNet, _ := CidrToIpv4("10.0.0.0/24")
+			if network != wantNet {
+				t.Fatalf("\tExpected network %v, got %v", wantNet, network)
+			}
+		}
+	}
+}